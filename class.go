@@ -0,0 +1,130 @@
+package errors
+
+import (
+	stderrors "errors"
+	"sync"
+)
+
+// Class is a factory for a family of related CError values that share a
+// default HTTP code and category, and that can be recognized as a group
+// via Is. Classes may be nested with Subclass to build hierarchies, e.g.
+//
+//	AuthClass := errors.NewClass(401, "auth")
+//	TokenClass := AuthClass.Subclass("token")
+//
+// errors.Is(err, AuthClass) then reports true for errors minted by
+// TokenClass as well.
+type Class struct {
+	name     string
+	code     uint16
+	category uint16
+	parent   *Class
+
+	mu   sync.Mutex
+	errs []*CError
+}
+
+// NewClass creates a top-level Class with the given default HTTP code.
+func NewClass(code uint16, name string) *Class {
+	return &Class{code: code, name: name}
+}
+
+// Subclass creates a Class nested under c, inheriting its code and category
+// by default. Both can be overridden with WithCode/WithCategory.
+func (c *Class) Subclass(name string) *Class {
+	return &Class{code: c.code, category: c.category, name: c.name + "." + name, parent: c}
+}
+
+// WithCode overrides the default HTTP code assigned to errors minted by c
+// and returns c for chaining at definition time.
+func (c *Class) WithCode(code uint16) *Class {
+	c.code = code
+	return c
+}
+
+// WithCategory sets the default category assigned to errors minted by c and
+// returns c for chaining at definition time.
+func (c *Class) WithCategory(category uint16) *Class {
+	c.category = category
+	return c
+}
+
+// Name returns the class's dotted name, e.g. "auth.token".
+func (c *Class) Name() string {
+	return c.name
+}
+
+// New creates a new CError with the class's default code and category,
+// tagged as belonging to c.
+func (c *Class) New(msg string) *CError {
+	e := New(c.code, msg).WithCategory(c.category).withClass(c)
+	c.register(e)
+	return e
+}
+
+// Wrap creates a new CError wrapping err with the class's default code and
+// category, tagged as belonging to c.
+func (c *Class) Wrap(err error, msg string) *CError {
+	e := Wrap(err, c.code, msg).WithCategory(c.category).withClass(c)
+	c.register(e)
+	return e
+}
+
+func (c *Class) register(e *CError) {
+	c.mu.Lock()
+	c.errs = append(c.errs, e)
+	c.mu.Unlock()
+}
+
+// Errors returns every CError minted directly from c via New or Wrap, in
+// creation order. It does not include errors minted from subclasses; use
+// Class.Errors on each subclass to enumerate those separately.
+func (c *Class) Errors() []*CError {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]*CError, len(c.errs))
+	copy(out, c.errs)
+	return out
+}
+
+func (e *CError) withClass(c *Class) *CError {
+	newErr := e.shallowCopy()
+	newErr.class = c
+	return &newErr
+}
+
+// Is reports whether err matches target. target may be a plain error, in
+// which case this delegates to the standard library's identity semantics,
+// or a *Class, in which case it reports whether any error in err's chain
+// was minted by that class or one of its subclasses.
+func Is(err error, target interface{}) bool {
+	if cls, ok := target.(*Class); ok {
+		return classMatches(err, cls)
+	}
+	if terr, ok := target.(error); ok {
+		return stderrors.Is(err, terr)
+	}
+	return false
+}
+
+func classMatches(err error, target *Class) bool {
+	for current := err; current != nil; {
+		cerr, ok := current.(*CError)
+		if !ok {
+			unwrapper, ok := current.(interface{ Unwrap() error })
+			if !ok {
+				return false
+			}
+			current = unwrapper.Unwrap()
+			continue
+		}
+
+		for c := cerr.class; c != nil; c = c.parent {
+			if c == target {
+				return true
+			}
+		}
+		current = cerr.wrapped
+	}
+	return false
+}
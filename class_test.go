@@ -0,0 +1,37 @@
+package errors
+
+import "testing"
+
+func TestIsMatchesSubclass(t *testing.T) {
+	base := NewClass(401, "auth")
+	token := base.Subclass("token")
+
+	err := token.New("invalid token")
+
+	if !Is(err, base) {
+		t.Fatal("Is should match an error minted by a subclass against its parent class")
+	}
+	if !Is(err, token) {
+		t.Fatal("Is should match an error against the class that minted it")
+	}
+
+	other := NewClass(404, "notfound")
+	if Is(err, other) {
+		t.Fatal("Is should not match an unrelated class")
+	}
+}
+
+func TestClassErrorsTracksOnlyDirectMembers(t *testing.T) {
+	base := NewClass(401, "auth")
+	sub := base.Subclass("token")
+
+	base.New("a")
+	sub.New("b")
+
+	if len(base.Errors()) != 1 {
+		t.Fatalf("base.Errors() = %d entries, want 1 (subclass errors excluded)", len(base.Errors()))
+	}
+	if len(sub.Errors()) != 1 {
+		t.Fatalf("sub.Errors() = %d entries, want 1", len(sub.Errors()))
+	}
+}
@@ -1,103 +1,145 @@
 package errors
 
+// ValidationClass groups all validation errors (400 Bad Request, CatInput).
+var ValidationClass = NewClass(400, "validation").WithCategory(CatInput)
+
 // Validation errors (400 Bad Request)
 var (
-	ErrValidationRequired    = New(400, "field is required")
-	ErrValidationInvalid     = New(400, "field value is invalid")
-	ErrValidationFormat      = New(400, "field format is invalid")
-	ErrValidationLength      = New(400, "field length is invalid")
-	ErrValidationRange       = New(400, "field value out of range")
-	ErrValidationEmail       = New(400, "invalid email format")
-	ErrValidationPhone       = New(400, "invalid phone format")
-	ErrValidationURL         = New(400, "invalid url format")
-	ErrValidationPassword    = New(400, "password does not meet requirements")
-	ErrValidationConfirm     = New(400, "confirmation does not match")
+	ErrValidationRequired = ValidationClass.New("field is required").WithType("/errors/validation-required")
+	ErrValidationInvalid  = ValidationClass.New("field value is invalid").WithType("/errors/validation-invalid")
+	ErrValidationFormat   = ValidationClass.New("field format is invalid").WithType("/errors/validation-format")
+	ErrValidationLength   = ValidationClass.New("field length is invalid").WithType("/errors/validation-length")
+	ErrValidationRange    = ValidationClass.New("field value out of range").WithType("/errors/validation-range")
+	ErrValidationEmail    = ValidationClass.New("invalid email format").WithType("/errors/validation-email")
+	ErrValidationPhone    = ValidationClass.New("invalid phone format").WithType("/errors/validation-phone")
+	ErrValidationURL      = ValidationClass.New("invalid url format").WithType("/errors/validation-url")
+	ErrValidationPassword = ValidationClass.New("password does not meet requirements").WithType("/errors/validation-password")
+	ErrValidationConfirm  = ValidationClass.New("confirmation does not match").WithType("/errors/validation-confirm")
+)
+
+// AuthClass groups authentication errors (401, CatAuth). ForbiddenClass and
+// TokenClass are subclasses for the 403 and token-specific cases, so
+// errors.Is(err, AuthClass) still matches errors minted by either.
+var (
+	AuthClass      = NewClass(401, "auth").WithCategory(CatAuth)
+	ForbiddenClass = AuthClass.Subclass("forbidden").WithCode(403)
+	TokenClass     = AuthClass.Subclass("token")
 )
 
 // Authentication errors
 var (
-	ErrAuthRequired          = New(401, "authentication required")
-	ErrAuthInvalid           = New(401, "invalid credentials")
-	ErrAuthExpired           = New(401, "authentication expired")
-	ErrAuthTokenInvalid      = New(401, "invalid token")
-	ErrAuthTokenExpired      = New(401, "token expired")
-	ErrAuthPermissions       = New(403, "insufficient permissions")
-	ErrAuthBlocked           = New(403, "account blocked")
-	ErrAuthSuspended         = New(403, "account suspended")
+	ErrAuthRequired     = AuthClass.New("authentication required").WithType("/errors/auth-required")
+	ErrAuthInvalid      = AuthClass.New("invalid credentials").WithType("/errors/auth-invalid")
+	ErrAuthExpired      = AuthClass.New("authentication expired").WithType("/errors/auth-expired")
+	ErrAuthTokenInvalid = TokenClass.New("invalid token").WithType("/errors/auth-token-invalid")
+	ErrAuthTokenExpired = TokenClass.New("token expired").WithType("/errors/auth-token-expired")
+	ErrAuthPermissions  = ForbiddenClass.New("insufficient permissions").WithType("/errors/auth-permissions")
+	ErrAuthBlocked      = ForbiddenClass.New("account blocked").WithType("/errors/auth-blocked")
+	ErrAuthSuspended    = ForbiddenClass.New("account suspended").WithType("/errors/auth-suspended")
 )
 
+// NotFoundClass groups not-found errors (404 Not Found, CatResource).
+var NotFoundClass = NewClass(404, "notfound").WithCategory(CatResource)
+
 // Not found errors (404 Not Found)
 var (
-	ErrNotFound             = New(404, "resource not found")
-	ErrUserNotFound         = New(404, "user not found")
-	ErrFileNotFound         = New(404, "file not found")
-	ErrPageNotFound         = New(404, "page not found")
-	ErrRecordNotFound       = New(404, "record not found")
-	ErrEndpointNotFound     = New(404, "endpoint not found")
+	ErrNotFound         = NotFoundClass.New("resource not found").WithType("/errors/notfound")
+	ErrUserNotFound     = NotFoundClass.New("user not found").WithType("/errors/notfound-user")
+	ErrFileNotFound     = NotFoundClass.New("file not found").WithType("/errors/notfound-file")
+	ErrPageNotFound     = NotFoundClass.New("page not found").WithType("/errors/notfound-page")
+	ErrRecordNotFound   = NotFoundClass.New("record not found").WithType("/errors/notfound-record")
+	ErrEndpointNotFound = NotFoundClass.New("endpoint not found").WithType("/errors/notfound-endpoint")
 )
 
+// ConflictClass groups conflict errors (409 Conflict, CatResource).
+var ConflictClass = NewClass(409, "conflict").WithCategory(CatResource)
+
 // Conflict errors (409 Conflict)
 var (
-	ErrConflict             = New(409, "resource conflict")
-	ErrAlreadyExists        = New(409, "resource already exists")
-	ErrUserExists           = New(409, "user already exists")
-	ErrEmailTaken           = New(409, "email already taken")
-	ErrDuplicateEntry       = New(409, "duplicate entry")
-	ErrVersionConflict      = New(409, "version conflict")
+	ErrConflict        = ConflictClass.New("resource conflict").WithType("/errors/conflict")
+	ErrAlreadyExists   = ConflictClass.New("resource already exists").WithType("/errors/conflict-already-exists")
+	ErrUserExists      = ConflictClass.New("user already exists").WithType("/errors/conflict-user-exists")
+	ErrEmailTaken      = ConflictClass.New("email already taken").WithType("/errors/conflict-email-taken")
+	ErrDuplicateEntry  = ConflictClass.New("duplicate entry").WithType("/errors/conflict-duplicate-entry")
+	ErrVersionConflict = ConflictClass.New("version conflict").WithType("/errors/conflict-version")
 )
 
+// BusinessClass groups business logic errors (422 Unprocessable Entity, CatSystem).
+var BusinessClass = NewClass(422, "business").WithCategory(CatSystem)
+
 // Business logic errors (422 Unprocessable Entity)
 var (
-	ErrUnprocessableEntity  = New(422, "unprocessable entity")
-	ErrBusinessRule         = New(422, "business rule violation")
-	ErrInsufficientFunds    = New(422, "insufficient funds")
-	ErrOperationNotAllowed  = New(422, "operation not allowed")
-	ErrLimitExceeded        = New(422, "limit exceeded")
-	ErrExpiredResource      = New(422, "resource expired")
-	ErrWorkflowError        = New(422, "workflow error")
+	ErrUnprocessableEntity = BusinessClass.New("unprocessable entity").WithType("/errors/business-unprocessable-entity")
+	ErrBusinessRule        = BusinessClass.New("business rule violation").WithType("/errors/business-rule")
+	ErrInsufficientFunds   = BusinessClass.New("insufficient funds").WithType("/errors/business-insufficient-funds")
+	ErrOperationNotAllowed = BusinessClass.New("operation not allowed").WithType("/errors/business-operation-not-allowed")
+	ErrLimitExceeded       = BusinessClass.New("limit exceeded").WithType("/errors/business-limit-exceeded")
+	ErrExpiredResource     = BusinessClass.New("resource expired").WithType("/errors/business-expired-resource")
+	ErrWorkflowError       = BusinessClass.New("workflow error").WithType("/errors/business-workflow-error")
 )
 
+// RateLimitClass groups rate limit errors (429 Too Many Requests, CatRateLimit).
+var RateLimitClass = NewClass(429, "ratelimit").WithCategory(CatRateLimit)
+
 // Rate limit errors (429 Too Many Requests)
 var (
-	ErrRateLimited          = New(429, "rate limit exceeded")
-	ErrTooManyRequests      = New(429, "too many requests")
-	ErrQuotaExceeded        = New(429, "quota exceeded")
-	ErrAPILimitReached      = New(429, "api limit reached")
+	ErrRateLimited     = RateLimitClass.New("rate limit exceeded").WithType("/errors/ratelimit-exceeded")
+	ErrTooManyRequests = RateLimitClass.New("too many requests").WithType("/errors/ratelimit-too-many-requests")
+	ErrQuotaExceeded   = RateLimitClass.New("quota exceeded").WithType("/errors/ratelimit-quota-exceeded")
+	ErrAPILimitReached = RateLimitClass.New("api limit reached").WithType("/errors/ratelimit-api-limit-reached")
 )
 
+// FileClass groups file operation errors (CatResource). Members override
+// the class default code since HTTP status varies per failure mode.
+var FileClass = NewClass(400, "file").WithCategory(CatResource)
+
 // File operation errors
 var (
-	ErrFileTooLarge         = New(413, "file too large")
-	ErrFileFormatInvalid    = New(415, "invalid file format")
-	ErrFileUploadFailed     = New(400, "file upload failed")
-	ErrFileProcessing       = New(422, "file processing error")
-	ErrStorageFull          = New(507, "storage full")
+	ErrFileTooLarge      = FileClass.New("file too large").WithCode(413).WithType("/errors/file-too-large")
+	ErrFileFormatInvalid = FileClass.New("invalid file format").WithCode(415).WithType("/errors/file-format-invalid")
+	ErrFileUploadFailed  = FileClass.New("file upload failed").WithType("/errors/file-upload-failed")
+	ErrFileProcessing    = FileClass.New("file processing error").WithCode(422).WithType("/errors/file-processing")
+	ErrStorageFull       = FileClass.New("storage full").WithCode(507).WithType("/errors/file-storage-full")
+)
+
+// ServerClass groups internal server errors (500, CatSystem). DatabaseClass
+// is a subclass for database-specific failures (CatDB).
+var (
+	ServerClass   = NewClass(500, "server").WithCategory(CatSystem)
+	DatabaseClass = ServerClass.Subclass("database").WithCategory(CatDB)
 )
 
 // Server errors
 var (
-	ErrInternalError        = New(500, "internal server error")
-	ErrDatabaseError        = New(500, "database error")
-	ErrTimeoutError         = New(504, "operation timeout")
-	ErrServiceUnavailable   = New(503, "service unavailable")
-	ErrMaintenanceMode      = New(503, "service under maintenance")
-	ErrExternalService      = New(502, "external service error")
+	ErrInternalError      = ServerClass.New("internal server error").WithType("/errors/server-internal")
+	ErrDatabaseError      = DatabaseClass.New("database error").WithType("/errors/server-database")
+	ErrTimeoutError       = ServerClass.New("operation timeout").WithCode(504).WithType("/errors/server-timeout")
+	ErrServiceUnavailable = ServerClass.New("service unavailable").WithCode(503).WithType("/errors/server-unavailable")
+	ErrMaintenanceMode    = ServerClass.New("service under maintenance").WithCode(503).WithType("/errors/server-maintenance")
+	ErrExternalService    = ServerClass.New("external service error").WithCode(502).WithType("/errors/server-external-service")
 )
 
+// NetworkClass groups network errors (CatSystem). Members override the
+// class default code since HTTP status varies per failure mode.
+var NetworkClass = NewClass(500, "network").WithCategory(CatSystem)
+
 // Network errors
 var (
-	ErrNetworkError         = New(500, "network error")
-	ErrConnectionRefused    = New(503, "connection refused")
-	ErrDNSError             = New(502, "dns resolution error")
-	ErrSSLError             = New(502, "ssl/tls error")
-	ErrProxyError           = New(502, "proxy error")
+	ErrNetworkError      = NetworkClass.New("network error").WithType("/errors/network-error")
+	ErrConnectionRefused = NetworkClass.New("connection refused").WithCode(503).WithType("/errors/network-connection-refused")
+	ErrDNSError          = NetworkClass.New("dns resolution error").WithCode(502).WithType("/errors/network-dns")
+	ErrSSLError          = NetworkClass.New("ssl/tls error").WithCode(502).WithType("/errors/network-ssl")
+	ErrProxyError        = NetworkClass.New("proxy error").WithCode(502).WithType("/errors/network-proxy")
 )
 
+// ParseClass groups parsing errors (400 Bad Request, CatInput).
+var ParseClass = NewClass(400, "parse").WithCategory(CatInput)
+
 // Parsing errors (400 Bad Request)
 var (
-	ErrParseError           = New(400, "parsing error")
-	ErrJSONInvalid          = New(400, "invalid json")
-	ErrXMLInvalid           = New(400, "invalid xml")
-	ErrFormatUnsupported    = New(415, "unsupported format")
-	ErrEncodingError        = New(400, "encoding error")
+	ErrParseError        = ParseClass.New("parsing error").WithType("/errors/parse-error")
+	ErrJSONInvalid       = ParseClass.New("invalid json").WithType("/errors/parse-json-invalid")
+	ErrXMLInvalid        = ParseClass.New("invalid xml").WithType("/errors/parse-xml-invalid")
+	ErrFormatUnsupported = ParseClass.New("unsupported format").WithCode(415).WithType("/errors/parse-format-unsupported")
+	ErrEncodingError     = ParseClass.New("encoding error").WithType("/errors/parse-encoding-error")
 )
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSentinelsHaveStableTypeURIs(t *testing.T) {
+	sentinels := []*CError{
+		ErrAuthRequired, ErrNotFound, ErrConflict, ErrUnprocessableEntity,
+		ErrRateLimited, ErrFileTooLarge, ErrInternalError, ErrNetworkError,
+		ErrParseError,
+	}
+
+	for _, s := range sentinels {
+		if s.Type() == "" {
+			t.Errorf("sentinel id %d has no explicit type", s.ID())
+		}
+		if problemType(s) == fmt.Sprintf("%s%d", DefaultTypePrefix, s.ID()) {
+			t.Errorf("sentinel id %d falls back to the unstable default type", s.ID())
+		}
+	}
+}
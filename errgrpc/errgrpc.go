@@ -0,0 +1,300 @@
+// Package errgrpc converts between gintokos/errors CError values and gRPC
+// status.Status, preserving sentinel identity, user-safe details, and the
+// wrap chain across the wire.
+package errgrpc
+
+import (
+	stderrors "errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	errs "gintokos/errors"
+	protov1 "github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// errorInfoDomain identifies details produced by this package inside
+// status.Details(), distinguishing them from details attached by other code.
+const errorInfoDomain = "gintokos/errors"
+
+// customDetailPrefix namespaces the CError extension keys used to carry
+// caller-registered detail messages (see WithCustomDetail) across
+// ToGRPC/FromGRPC, so they don't collide with ordinary extensions.
+const customDetailPrefix = "errgrpc.detail."
+
+// DetailFactory builds an empty instance of a custom proto.Message so it
+// can be recognized among a status's details.
+type DetailFactory func() proto.Message
+
+var (
+	registry = map[string]DetailFactory{}
+)
+
+// RegisterDetail registers a factory for a custom proto.Message type under
+// name, analogous to typeurl registration. Types registered here are not
+// required for round-tripping CError itself (that always uses ErrorInfo and
+// DebugInfo below); it lets callers attach and later recognize additional
+// application-specific detail messages that should survive ToGRPC/FromGRPC.
+func RegisterDetail(name string, factory DetailFactory) {
+	registry[name] = factory
+}
+
+// WithCustomDetail attaches a custom, application-specific detail message to
+// err under name (as registered via RegisterDetail) so that ToGRPC includes
+// it among the outgoing status details and FromGRPC recovers it on the other
+// end (see CustomDetail). It is a no-op if err does not wrap a *errs.CError.
+func WithCustomDetail(err error, name string, msg proto.Message) error {
+	var cerr *errs.CError
+	if !stderrors.As(err, &cerr) {
+		return err
+	}
+	return cerr.WithExtension(customDetailPrefix+name, msg)
+}
+
+// CustomDetail returns the custom detail message attached under name, either
+// directly via WithCustomDetail or recovered by FromGRPC, and whether one
+// was present.
+func CustomDetail(err error, name string) (proto.Message, bool) {
+	var cerr *errs.CError
+	if !stderrors.As(err, &cerr) {
+		return nil, false
+	}
+	val, ok := cerr.Extensions()[customDetailPrefix+name]
+	if !ok {
+		return nil, false
+	}
+	msg, ok := val.(proto.Message)
+	return msg, ok
+}
+
+// registeredDetailName reports the name msg was registered under via
+// RegisterDetail, matching by protobuf message type rather than Go type so
+// it works across package boundaries.
+func registeredDetailName(msg proto.Message) (string, bool) {
+	for name, factory := range registry {
+		if proto.MessageName(msg) == proto.MessageName(factory()) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// httpToGRPCCode maps an HTTP status code to the canonical gRPC code.
+func httpToGRPCCode(code int) codes.Code {
+	switch code {
+	case 400:
+		return codes.InvalidArgument
+	case 401:
+		return codes.Unauthenticated
+	case 403:
+		return codes.PermissionDenied
+	case 404:
+		return codes.NotFound
+	case 409:
+		return codes.AlreadyExists
+	case 422:
+		return codes.FailedPrecondition
+	case 429:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case 500:
+		return codes.Internal
+	case 501:
+		return codes.Unimplemented
+	case 503:
+		return codes.Unavailable
+	case 504:
+		return codes.DeadlineExceeded
+	}
+
+	switch {
+	case code >= 500:
+		return codes.Internal
+	case code >= 400:
+		return codes.InvalidArgument
+	default:
+		return codes.Unknown
+	}
+}
+
+// grpcToHTTPCode maps a gRPC code back to a representative HTTP status,
+// used when synthesizing a CError purely from a gRPC code.
+func grpcToHTTPCode(code codes.Code) uint16 {
+	switch code {
+	case codes.InvalidArgument:
+		return 400
+	case codes.Unauthenticated:
+		return 401
+	case codes.PermissionDenied:
+		return 403
+	case codes.NotFound:
+		return 404
+	case codes.AlreadyExists:
+		return 409
+	case codes.FailedPrecondition:
+		return 422
+	case codes.ResourceExhausted:
+		return 429
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return 501
+	case codes.Unavailable:
+		return 503
+	case codes.DeadlineExceeded:
+		return 504
+	case codes.OK:
+		return 200
+	default:
+		return 500
+	}
+}
+
+// ToGRPC converts err to a gRPC status error. If err (or any error in its
+// chain) is a *errs.CError, its code, message, details, and wrap chain are
+// preserved as status details; otherwise err is mapped to Internal.
+func ToGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var cerr *errs.CError
+	if !stderrors.As(err, &cerr) {
+		return status.New(codes.Internal, err.Error()).Err()
+	}
+
+	st := status.New(httpToGRPCCode(cerr.Code()), cerr.Message())
+
+	info := &errdetails.ErrorInfo{
+		Reason: strconv.FormatInt(int64(cerr.ID()), 10),
+		Domain: errorInfoDomain,
+		Metadata: map[string]string{
+			"code":        strconv.Itoa(cerr.Code()),
+			"msg":         cerr.Message(),
+			"details":     strings.Join(cerr.Details(), "\x1f"),
+			"userdetails": strings.Join(cerr.UserDetails(), "\x1f"),
+		},
+	}
+
+	var debugInfo *errdetails.DebugInfo
+	if chain := flattenChain(cerr); len(chain) > 0 {
+		debugInfo = &errdetails.DebugInfo{StackEntries: chain}
+	}
+
+	// status.WithDetails wants github.com/golang/protobuf/proto.Message
+	// (the v1 interface), not the google.golang.org/protobuf/proto.Message
+	// (v2) used for DetailFactory/custom details above; the generated
+	// message types satisfy both, but the two interfaces don't convert as a
+	// slice, so each detail is type-asserted to v1 individually.
+	v1Details := make([]protov1.Message, 0, 2+len(cerr.Extensions()))
+	v1Details = append(v1Details, info)
+	if debugInfo != nil {
+		v1Details = append(v1Details, debugInfo)
+	}
+	for key, val := range cerr.Extensions() {
+		if !strings.HasPrefix(key, customDetailPrefix) {
+			continue
+		}
+		if msg, ok := val.(protov1.Message); ok {
+			v1Details = append(v1Details, msg)
+		}
+	}
+
+	stWithDetails, detailErr := st.WithDetails(v1Details...)
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return stWithDetails.Err()
+}
+
+// flattenChain renders each wrapped CError as a single "id|code|msg" line,
+// and any other wrapped error as its Error() text, so the full chain
+// survives inside a DebugInfo detail.
+func flattenChain(cerr *errs.CError) []string {
+	var lines []string
+	for _, wrapped := range cerr.UnwrapAll() {
+		if wc, ok := wrapped.(*errs.CError); ok {
+			lines = append(lines, fmt.Sprintf("%d|%d|%s", wc.ID(), wc.Code(), wc.Message()))
+			continue
+		}
+		lines = append(lines, wrapped.Error())
+	}
+	return lines
+}
+
+// FromGRPC reconstructs a CError from a gRPC status error. When the status
+// carries the ErrorInfo detail produced by ToGRPC, the original sentinel is
+// looked up by id (via errs.Lookup) so errors.Is keeps matching it;
+// otherwise a CError is synthesized from the gRPC code alone.
+func FromGRPC(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return errs.ErrInternalError.WithWrap(err)
+	}
+
+	var cerr *errs.CError
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != errorInfoDomain {
+			continue
+		}
+
+		id, parseErr := strconv.ParseInt(info.GetReason(), 10, 32)
+		if parseErr != nil {
+			break
+		}
+
+		cerr = errs.ErrInternalError
+		if sentinel, ok := errs.Lookup(int32(id)); ok {
+			cerr = sentinel
+		}
+
+		meta := info.GetMetadata()
+		if code, convErr := strconv.Atoi(meta["code"]); convErr == nil {
+			cerr = cerr.WithCode(uint16(code))
+		}
+		if msg := meta["msg"]; msg != "" {
+			cerr = cerr.WithMessage(msg)
+		}
+		for _, detail := range splitNonEmpty(meta["details"]) {
+			cerr = cerr.WithDetail(detail)
+		}
+		for _, detail := range splitNonEmpty(meta["userdetails"]) {
+			cerr = cerr.WithUserDetail(detail)
+		}
+		break
+	}
+
+	if cerr == nil {
+		return errs.New(grpcToHTTPCode(st.Code()), st.Message())
+	}
+
+	for _, d := range st.Details() {
+		msg, ok := d.(proto.Message)
+		if !ok {
+			continue
+		}
+		if name, ok := registeredDetailName(msg); ok {
+			cerr = cerr.WithExtension(customDetailPrefix+name, msg)
+		}
+	}
+
+	return cerr
+}
+
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\x1f")
+}
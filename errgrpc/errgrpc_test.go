@@ -0,0 +1,74 @@
+package errgrpc
+
+import (
+	stderrors "errors"
+	"testing"
+
+	errs "gintokos/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToGRPCRoundTripsSentinelIdentity(t *testing.T) {
+	orig := errs.ErrNotFound.WithUserDetail("widget 42 does not exist")
+
+	st := ToGRPC(orig)
+	back := FromGRPC(st)
+
+	var cerr *errs.CError
+	if !stderrors.As(back, &cerr) {
+		t.Fatalf("FromGRPC result is not a *CError: %v", back)
+	}
+	if !stderrors.Is(cerr, errs.ErrNotFound) {
+		t.Fatalf("round-tripped error lost sentinel identity: %v", cerr)
+	}
+
+	found := false
+	for _, d := range cerr.UserDetails() {
+		if d == "widget 42 does not exist" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("round-tripped error lost its user detail: %v", cerr.UserDetails())
+	}
+}
+
+func TestToGRPCNonCError(t *testing.T) {
+	err := stderrors.New("boom")
+	st := ToGRPC(err)
+	if st.Error() == "" {
+		t.Fatal("expected a non-empty status error")
+	}
+}
+
+func TestCustomDetailRoundTrips(t *testing.T) {
+	const name = "quota_failure"
+	RegisterDetail(name, func() proto.Message { return &errdetails.QuotaFailure{} })
+
+	withDetail := WithCustomDetail(errs.ErrRateLimited, name, &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{
+			{Subject: "user:42", Description: "too many requests"},
+		},
+	})
+
+	back := FromGRPC(ToGRPC(withDetail))
+
+	got, ok := CustomDetail(back, name)
+	if !ok {
+		t.Fatalf("custom detail %q did not survive the round trip", name)
+	}
+	qf, ok := got.(*errdetails.QuotaFailure)
+	if !ok || len(qf.Violations) != 1 || qf.Violations[0].Subject != "user:42" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestToGRPCNil(t *testing.T) {
+	if ToGRPC(nil) != nil {
+		t.Fatal("ToGRPC(nil) should return nil")
+	}
+	if FromGRPC(nil) != nil {
+		t.Fatal("FromGRPC(nil) should return nil")
+	}
+}
@@ -4,11 +4,30 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"sync/atomic"
 )
 
 var errorCounter int32
 
+// sentinels holds every CError created via New, keyed by id, so that a
+// numeric id received over the wire (e.g. from errgrpc) can be resolved
+// back to the originating sentinel and preserve errors.Is identity.
+var (
+	sentinelsMu sync.RWMutex
+	sentinels   = map[int32]*CError{}
+)
+
+// Lookup returns the sentinel CError registered under id, if any. It is
+// primarily used by wire-format converters (see errgrpc) that need to
+// recover the original sentinel identity from a numeric id.
+func Lookup(id int32) (*CError, bool) {
+	sentinelsMu.RLock()
+	defer sentinelsMu.RUnlock()
+	e, ok := sentinels[id]
+	return e, ok
+}
+
 // CError represents a custom error with code, message, and optional details.
 // It supports error wrapping and provides two levels of details:
 // - details: technical details for logging and debugging
@@ -20,39 +39,57 @@ var errorCounter int32
 // - Errors with the same ID are considered equal even with different messages/codes
 // - This allows reusing the same logical error in different contexts
 type CError struct {
-	wrapped     error
-	details     []string
-	userdetails []string
-	msg         string
-	id          int32
-	code        uint16
+	wrapped      error
+	details      []string
+	userdetails  []string
+	extensions   map[string]any
+	msg          string
+	problemType  string
+	id           int32
+	code         uint16
+	scope        uint16
+	category     uint16
+	detailCode   uint16
+	class        *Class
+	messageKey   string
+	messageArgs  map[string]any
+	codeResolver func(*CError) uint16
+	codeCache    *codeCache
 }
 
 // New creates a new CError with the given code and message.
 func New(code uint16, msg string) *CError {
 	id := atomic.AddInt32(&errorCounter, 1)
-	return &CError{
-		id:   id,
-		code: code,
-		msg:  msg,
+	e := &CError{
+		id:        id,
+		code:      code,
+		msg:       msg,
+		codeCache: &codeCache{},
 	}
+
+	sentinelsMu.Lock()
+	sentinels[id] = e
+	sentinelsMu.Unlock()
+
+	return e
 }
 
 // Wrap creates a new CError that wraps the given error with additional code and message.
 func Wrap(err error, code uint16, msg string) *CError {
 	id := atomic.AddInt32(&errorCounter, 1)
 	return &CError{
-		id:      id,
-		wrapped: err,
-		code:    code,
-		msg:     msg,
+		id:        id,
+		wrapped:   err,
+		code:      code,
+		msg:       msg,
+		codeCache: &codeCache{},
 	}
 }
 
 func (e *CError) Error() string {
 	var errstr strings.Builder
 
-	errstr.WriteString(fmt.Sprintf("message: %s, code: %d", e.msg, e.code))
+	errstr.WriteString(fmt.Sprintf("message: %s, code: %d", e.msg, e.Code()))
 	if e.details != nil {
 		errstr.WriteString(", details: [")
 		for i, detail := range e.details {
@@ -71,9 +108,15 @@ func (e *CError) Error() string {
 	return errstr.String()
 }
 
-// Code returns the error code as int.
-func (e *CError) Code() int {
-	return int(e.code)
+// ID returns the error's unique identity, the same value compared by Is.
+func (e *CError) ID() int32 {
+	return e.id
+}
+
+// Class returns the Class that minted e, or nil if it was created directly
+// via New or Wrap.
+func (e *CError) Class() *Class {
+	return e.class
 }
 
 // Message returns the error message.
@@ -142,28 +185,28 @@ func (c *CError) UnwrapAll() []error {
 
 // WithCode sets the error code and returns a new error copy for chaining.
 func (e *CError) WithCode(code uint16) *CError {
-	newErr := *e
+	newErr := e.shallowCopy()
 	newErr.code = code
 	return &newErr
 }
 
 // WithMessage sets the error message and returns a new error copy for chaining.
 func (e *CError) WithMessage(msg string) *CError {
-	newErr := *e
+	newErr := e.shallowCopy()
 	newErr.msg = msg
 	return &newErr
 }
 
 // WithWrap wraps another error and returns a new error copy for chaining.
 func (e *CError) WithWrap(err error) *CError {
-	newErr := *e
+	newErr := e.shallowCopy()
 	newErr.wrapped = err
 	return &newErr
 }
 
 // WithDetail adds a technical detail to the error and returns a new error copy for chaining.
 func (e *CError) WithDetail(detail string) *CError {
-	newErr := *e
+	newErr := e.shallowCopy()
 	newErr.details = make([]string, len(e.details), len(e.details)+1)
 	copy(newErr.details, e.details)
 	newErr.details = append(newErr.details, detail)
@@ -172,13 +215,41 @@ func (e *CError) WithDetail(detail string) *CError {
 
 // WithUserDetail adds a user-safe detail to the error and returns a new error copy for chaining.
 func (e *CError) WithUserDetail(detail string) *CError {
-	newErr := *e
+	newErr := e.shallowCopy()
 	newErr.userdetails = make([]string, len(e.userdetails), len(e.userdetails)+1)
 	copy(newErr.userdetails, e.userdetails)
 	newErr.userdetails = append(newErr.userdetails, detail)
 	return &newErr
 }
 
+// WithType sets a stable problem type URI (see WriteProblem) and returns a new error copy for chaining.
+func (e *CError) WithType(typeURI string) *CError {
+	newErr := e.shallowCopy()
+	newErr.problemType = typeURI
+	return &newErr
+}
+
+// WithExtension attaches an arbitrary key/value extension member and returns a new error copy for chaining.
+func (e *CError) WithExtension(key string, val any) *CError {
+	newErr := e.shallowCopy()
+	newErr.extensions = make(map[string]any, len(e.extensions)+1)
+	for k, v := range e.extensions {
+		newErr.extensions[k] = v
+	}
+	newErr.extensions[key] = val
+	return &newErr
+}
+
+// Type returns the error's problem type URI, or "" if none was set via WithType.
+func (e *CError) Type() string {
+	return e.problemType
+}
+
+// Extensions returns the extension members attached via WithExtension.
+func (e *CError) Extensions() map[string]any {
+	return e.extensions
+}
+
 // UserMessage returns a user-friendly error message.
 func (e *CError) UserMessage() string {
 	if e.msg != "" {
@@ -193,7 +264,7 @@ func (e *CError) IsCode(code int) bool {
 	if code < 0 || code > 65535 {
 		return false
 	}
-	return e.code == uint16(code)
+	return e.Code() == code
 }
 
 // MarshalJSON serializes the error to JSON with minimal user-safe information.
@@ -201,15 +272,21 @@ func (e *CError) IsCode(code int) bool {
 // Technical details, wrapped errors, and internal IDs are excluded for security.
 func (e *CError) MarshalJSON() ([]byte, error) {
 	type errorJSON struct {
-		Message string   `json:"message"`
-		Code    uint16   `json:"code"`
-		Details []string `json:"details,omitempty"`
+		Message    string   `json:"message"`
+		Code       uint16   `json:"code"`
+		Details    []string `json:"details,omitempty"`
+		Scope      uint16   `json:"scope,omitempty"`
+		Category   uint16   `json:"category,omitempty"`
+		DetailCode uint16   `json:"detail_code,omitempty"`
 	}
 
 	data := errorJSON{
-		Message: e.msg,
-		Code:    e.code,
-		Details: e.userdetails,
+		Message:    e.msg,
+		Code:       uint16(e.Code()),
+		Details:    e.userdetails,
+		Scope:      e.scope,
+		Category:   e.category,
+		DetailCode: e.detailCode,
 	}
 
 	return json.Marshal(data)
@@ -217,9 +294,12 @@ func (e *CError) MarshalJSON() ([]byte, error) {
 
 func (e *CError) UnmarshalJSON(data []byte) error {
 	type errorJSON struct {
-		Message string   `json:"message"`
-		Code    uint16   `json:"code"`
-		Details []string `json:"details,omitempty"`
+		Message    string   `json:"message"`
+		Code       uint16   `json:"code"`
+		Details    []string `json:"details,omitempty"`
+		Scope      uint16   `json:"scope,omitempty"`
+		Category   uint16   `json:"category,omitempty"`
+		DetailCode uint16   `json:"detail_code,omitempty"`
 	}
 
 	var tmp errorJSON
@@ -230,6 +310,9 @@ func (e *CError) UnmarshalJSON(data []byte) error {
 	e.msg = tmp.Message
 	e.code = tmp.Code
 	e.userdetails = tmp.Details
+	e.scope = tmp.Scope
+	e.category = tmp.Category
+	e.detailCode = tmp.DetailCode
 
 	return nil
 }
@@ -241,7 +324,13 @@ func (e *CError) UnmarshalJSON(data []byte) error {
 func (e *CError) FullData() map[string]interface{} {
 	data := map[string]interface{}{
 		"message": e.msg,
-		"code":    e.code,
+		"code":    e.Code(),
+	}
+
+	if e.scope != 0 || e.category != 0 || e.detailCode != 0 {
+		data["scope"] = e.scope
+		data["category"] = e.category
+		data["detail_code"] = e.detailCode
 	}
 
 	if len(e.details) > 0 {
@@ -271,7 +360,7 @@ func (e *CError) expandAllWrapped(err error) []map[string]interface{} {
 
 		if cerr, ok := current.(*CError); ok {
 			wrappedData["message"] = cerr.msg
-			wrappedData["code"] = cerr.code
+			wrappedData["code"] = cerr.Code()
 
 			if len(cerr.details) > 0 {
 				wrappedData["details"] = cerr.details
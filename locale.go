@@ -0,0 +1,338 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Catalog maps an error's identity (by id, or by a stable key set via
+// WithMessageKey) to per-locale message templates. Templates may reference
+// named placeholders substituted from UserDetails or WithMessageArgs, using
+// the small ICU-subset syntax described on formatICU.
+type Catalog struct {
+	mu       sync.RWMutex
+	byID     map[int32]map[string]string
+	byKey    map[string]map[string]string
+	fallback string
+}
+
+// NewCatalog creates an empty Catalog. fallbackLocale is used when a
+// translation is missing for the requested locale (e.g. "en").
+func NewCatalog(fallbackLocale string) *Catalog {
+	return &Catalog{
+		byID:     map[int32]map[string]string{},
+		byKey:    map[string]map[string]string{},
+		fallback: fallbackLocale,
+	}
+}
+
+// Set registers a template for id in locale.
+func (c *Catalog) Set(id int32, locale, template string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byID[id] == nil {
+		c.byID[id] = map[string]string{}
+	}
+	c.byID[id][locale] = template
+}
+
+// SetByKey registers a template for a stable message key in locale, for use
+// with CError.WithMessageKey.
+func (c *Catalog) SetByKey(key, locale, template string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.byKey[key] == nil {
+		c.byKey[key] = map[string]string{}
+	}
+	c.byKey[key][locale] = template
+}
+
+// lookup finds the best template for e in locale: an exact (key, locale) or
+// (id, locale) match, then the same keyed by the catalog's fallback locale.
+func (c *Catalog) lookup(e *CError, locale string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	candidates := []map[string]string{}
+	if e.messageKey != "" {
+		if m, ok := c.byKey[e.messageKey]; ok {
+			candidates = append(candidates, m)
+		}
+	}
+	if m, ok := c.byID[e.id]; ok {
+		candidates = append(candidates, m)
+	}
+
+	for _, m := range candidates {
+		if tmpl, ok := m[locale]; ok {
+			return tmpl, true
+		}
+	}
+	for _, m := range candidates {
+		if tmpl, ok := m[c.fallback]; ok {
+			return tmpl, true
+		}
+	}
+	return "", false
+}
+
+var (
+	defaultCatalogMu sync.RWMutex
+	defaultCatalog   *Catalog
+)
+
+// SetDefaultCatalog installs the catalog used by LocalizedUserMessage and
+// MarshalJSONForLocale when no request-scoped catalog is available via
+// WithCatalog.
+func SetDefaultCatalog(cat *Catalog) {
+	defaultCatalogMu.Lock()
+	defaultCatalog = cat
+	defaultCatalogMu.Unlock()
+}
+
+func getDefaultCatalog() *Catalog {
+	defaultCatalogMu.RLock()
+	defer defaultCatalogMu.RUnlock()
+	return defaultCatalog
+}
+
+type catalogContextKey struct{}
+
+// WithCatalog returns a copy of ctx carrying cat, so a request-scoped
+// catalog can override the package default for the lifetime of a request.
+func WithCatalog(ctx context.Context, cat *Catalog) context.Context {
+	return context.WithValue(ctx, catalogContextKey{}, cat)
+}
+
+// CatalogFromContext returns the catalog attached via WithCatalog, falling
+// back to the default catalog set with SetDefaultCatalog.
+func CatalogFromContext(ctx context.Context) *Catalog {
+	if cat, ok := ctx.Value(catalogContextKey{}).(*Catalog); ok && cat != nil {
+		return cat
+	}
+	return getDefaultCatalog()
+}
+
+// WithMessageKey sets a stable string key used to look up localized
+// messages independent of the error's numeric id, and returns a new error
+// copy for chaining.
+func (e *CError) WithMessageKey(key string) *CError {
+	newErr := e.shallowCopy()
+	newErr.messageKey = key
+	return &newErr
+}
+
+// WithMessageArgs sets the named placeholder values substituted into the
+// localized message template, and returns a new error copy for chaining.
+func (e *CError) WithMessageArgs(args map[string]any) *CError {
+	newErr := e.shallowCopy()
+	newErr.messageArgs = args
+	return &newErr
+}
+
+// LocalizedUserMessage returns the message for e translated into lang using
+// the default catalog (see SetDefaultCatalog), falling back to
+// UserMessage() when no catalog is configured or no translation exists.
+func (e *CError) LocalizedUserMessage(lang string) string {
+	return e.localizedUserMessage(getDefaultCatalog(), lang)
+}
+
+// LocalizedUserMessageContext is like LocalizedUserMessage but resolves the
+// catalog from ctx (see WithCatalog) instead of the package default.
+func (e *CError) LocalizedUserMessageContext(ctx context.Context, lang string) string {
+	return e.localizedUserMessage(CatalogFromContext(ctx), lang)
+}
+
+func (e *CError) localizedUserMessage(cat *Catalog, lang string) string {
+	if cat == nil {
+		return e.UserMessage()
+	}
+	tmpl, ok := cat.lookup(e, lang)
+	if !ok {
+		return e.UserMessage()
+	}
+	return formatICU(tmpl, e.argsForFormatting())
+}
+
+func (e *CError) argsForFormatting() map[string]any {
+	if len(e.messageArgs) == 0 && len(e.userdetails) == 0 {
+		return nil
+	}
+
+	args := make(map[string]any, len(e.messageArgs)+1)
+	for k, v := range e.messageArgs {
+		args[k] = v
+	}
+	if len(e.userdetails) > 0 {
+		args["userdetails"] = strings.Join(e.userdetails, "; ")
+	}
+	return args
+}
+
+// MarshalJSONForLocale is like MarshalJSON but renders message using the
+// default catalog translated into lang, keeping Error() (used for logs)
+// on the canonical English message.
+func (e *CError) MarshalJSONForLocale(lang string) ([]byte, error) {
+	return e.marshalJSONLocalized(getDefaultCatalog(), lang)
+}
+
+// MarshalJSONContext is like MarshalJSONForLocale but resolves the catalog
+// from ctx (see WithCatalog) instead of the package default.
+func (e *CError) MarshalJSONContext(ctx context.Context, lang string) ([]byte, error) {
+	return e.marshalJSONLocalized(CatalogFromContext(ctx), lang)
+}
+
+func (e *CError) marshalJSONLocalized(cat *Catalog, lang string) ([]byte, error) {
+	type errorJSON struct {
+		Message    string   `json:"message"`
+		Code       uint16   `json:"code"`
+		Details    []string `json:"details,omitempty"`
+		Scope      uint16   `json:"scope,omitempty"`
+		Category   uint16   `json:"category,omitempty"`
+		DetailCode uint16   `json:"detail_code,omitempty"`
+	}
+
+	data := errorJSON{
+		Message:    e.localizedUserMessage(cat, lang),
+		Code:       uint16(e.Code()),
+		Details:    e.userdetails,
+		Scope:      e.scope,
+		Category:   e.category,
+		DetailCode: e.detailCode,
+	}
+
+	return json.Marshal(data)
+}
+
+// formatICU renders template against args using a small ICU subset:
+//   - "{name}" is replaced with args["name"]
+//   - "{name, plural, one {...} other {...}}" selects the "one" branch when
+//     args["name"] equals 1 (or "one" for pre-selected plural categories),
+//     and "other" otherwise; "#" inside the selected branch is replaced with
+//     the numeric value of args["name"]
+//
+// Unknown placeholders and malformed plural clauses are left verbatim so a
+// bad template degrades gracefully instead of panicking.
+func formatICU(template string, args map[string]any) string {
+	var out strings.Builder
+	i := 0
+	for i < len(template) {
+		if template[i] != '{' {
+			out.WriteByte(template[i])
+			i++
+			continue
+		}
+
+		end := matchingBrace(template, i)
+		if end < 0 {
+			out.WriteString(template[i:])
+			break
+		}
+
+		out.WriteString(formatICUExpr(template[i+1:end], args))
+		i = end + 1
+	}
+	return out.String()
+}
+
+func formatICUExpr(expr string, args map[string]any) string {
+	parts := strings.SplitN(expr, ",", 3)
+	name := strings.TrimSpace(parts[0])
+	val, ok := args[name]
+
+	if len(parts) < 3 || strings.TrimSpace(parts[1]) != "plural" {
+		if !ok {
+			return "{" + expr + "}"
+		}
+		return stringifyArg(val)
+	}
+
+	if !ok {
+		return "{" + expr + "}"
+	}
+	branches := parsePluralBranches(parts[2])
+	category := pluralCategory(val)
+	branch, found := branches[category]
+	if !found {
+		branch, found = branches["other"]
+	}
+	if !found {
+		return "{" + expr + "}"
+	}
+	return strings.ReplaceAll(branch, "#", stringifyArg(val))
+}
+
+func parsePluralBranches(s string) map[string]string {
+	branches := map[string]string{}
+	i := 0
+	for i < len(s) {
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+			i++
+		}
+		start := i
+		for i < len(s) && s[i] != '{' {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		label := strings.TrimSpace(s[start:i])
+
+		end := matchingBrace(s, i)
+		if end < 0 {
+			break
+		}
+		branches[label] = s[i+1 : end]
+		i = end + 1
+	}
+	return branches
+}
+
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func pluralCategory(val any) string {
+	switch v := stringifyArg(val); v {
+	case "1", "one":
+		return "one"
+	default:
+		return "other"
+	}
+}
+
+func stringifyArg(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		if val == nil {
+			return ""
+		}
+		return fmt.Sprintf("%v", val)
+	}
+}
@@ -0,0 +1,50 @@
+package errors
+
+import "testing"
+
+func TestLocalizedUserMessageFallsBackToCatalogDefault(t *testing.T) {
+	cat := NewCatalog("en")
+	err := New(400, "invalid email").WithMessageKey("validation.email")
+
+	cat.SetByKey("validation.email", "en", "the email address is invalid")
+	cat.SetByKey("validation.email", "fr", "l'adresse e-mail est invalide")
+
+	if got := err.localizedUserMessage(cat, "fr"); got != "l'adresse e-mail est invalide" {
+		t.Fatalf("fr translation = %q", got)
+	}
+	if got := err.localizedUserMessage(cat, "de"); got != "the email address is invalid" {
+		t.Fatalf("missing de translation should fall back to catalog default, got %q", got)
+	}
+}
+
+func TestLocalizedUserMessageWithoutCatalogUsesUserMessage(t *testing.T) {
+	err := New(400, "invalid email")
+	if got := err.localizedUserMessage(nil, "fr"); got != err.UserMessage() {
+		t.Fatalf("got %q, want UserMessage() fallback", got)
+	}
+}
+
+func TestFormatICUPlural(t *testing.T) {
+	tmpl := "{count, plural, one {# item} other {# items}}"
+
+	if got := formatICU(tmpl, map[string]any{"count": 1}); got != "1 item" {
+		t.Fatalf("singular = %q", got)
+	}
+	if got := formatICU(tmpl, map[string]any{"count": 5}); got != "5 items" {
+		t.Fatalf("plural = %q", got)
+	}
+}
+
+func TestFormatICUSimplePlaceholder(t *testing.T) {
+	got := formatICU("hello {name}", map[string]any{"name": "Ada"})
+	if got != "hello Ada" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestFormatICUUnknownPlaceholderLeftVerbatim(t *testing.T) {
+	got := formatICU("hello {name}", nil)
+	if got != "hello {name}" {
+		t.Fatalf("got %q, want the placeholder left untouched", got)
+	}
+}
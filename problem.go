@@ -0,0 +1,168 @@
+package errors
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultTypePrefix is prepended to an error's id when it has no explicit
+// type set via WithType, producing an opaque problem type URI that depends
+// on the order in which errors are created.
+const DefaultTypePrefix = "/errors/"
+
+// MediaTypeProblemJSON and MediaTypeProblemXML are the RFC 7807 media types
+// negotiated by WriteProblem.
+const (
+	MediaTypeProblemJSON = "application/problem+json"
+	MediaTypeProblemXML  = "application/problem+xml"
+)
+
+// Problem is an RFC 7807 "Problem Details for HTTP APIs" view of a CError.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// NewProblem builds a Problem from err. Instance should be the URI of the
+// request that produced the error (e.g. r.URL.Path); it may be empty.
+//
+// Non-CError errors are mapped to ErrInternalError so the response still
+// carries a well-formed problem body.
+func NewProblem(err error, instance string) *Problem {
+	var cerr *CError
+	if !stderrors.As(err, &cerr) {
+		cerr = ErrInternalError.WithWrap(err)
+	}
+
+	title := cerr.msg
+	if title == "" {
+		title = cerr.UserMessage()
+	}
+
+	var detail string
+	if len(cerr.userdetails) > 0 {
+		detail = strings.Join(cerr.userdetails, "; ")
+	}
+
+	return &Problem{
+		Type:       problemType(cerr),
+		Title:      title,
+		Status:     cerr.Code(),
+		Detail:     detail,
+		Instance:   instance,
+		Extensions: problemExtensions(cerr),
+	}
+}
+
+func problemType(e *CError) string {
+	if e.problemType != "" {
+		return e.problemType
+	}
+	return fmt.Sprintf("%s%d", DefaultTypePrefix, e.id)
+}
+
+// problemExtensions returns e's extension members for the "extensions"
+// area of the problem body. userdetails is deliberately not duplicated
+// here; it is already the sole source of the standard Detail field (see
+// NewProblem).
+func problemExtensions(e *CError) map[string]any {
+	if len(e.extensions) == 0 {
+		return nil
+	}
+
+	ext := make(map[string]any, len(e.extensions))
+	for k, v := range e.extensions {
+		ext[k] = v
+	}
+	return ext
+}
+
+// MarshalJSON serializes p as a flat JSON object per RFC 7807, with
+// extension members merged in alongside the standard fields.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	data := make(map[string]any, 5+len(p.Extensions))
+	for k, v := range p.Extensions {
+		data[k] = v
+	}
+
+	data["type"] = p.Type
+	data["title"] = p.Title
+	data["status"] = p.Status
+	if p.Detail != "" {
+		data["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		data["instance"] = p.Instance
+	}
+
+	return json.Marshal(data)
+}
+
+// problemXML mirrors Problem for XML encoding, since Go's encoding/xml
+// cannot marshal a map[string]any directly.
+type problemXML struct {
+	XMLName  xml.Name `xml:"problem"`
+	Type     string   `xml:"type"`
+	Title    string   `xml:"title"`
+	Status   int      `xml:"status"`
+	Detail   string   `xml:"detail,omitempty"`
+	Instance string   `xml:"instance,omitempty"`
+}
+
+// WriteProblem writes err to w as an RFC 7807 problem response, negotiating
+// application/problem+json or application/problem+xml from the request's
+// Accept header (defaulting to JSON) and using the error's Code() as the
+// HTTP status.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	instance := ""
+	if r != nil && r.URL != nil {
+		instance = r.URL.Path
+	}
+	problem := NewProblem(err, instance)
+
+	if acceptsXML(r) {
+		w.Header().Set("Content-Type", MediaTypeProblemXML)
+		w.WriteHeader(problem.Status)
+		_ = xml.NewEncoder(w).Encode(problemXML{
+			Type:     problem.Type,
+			Title:    problem.Title,
+			Status:   problem.Status,
+			Detail:   problem.Detail,
+			Instance: problem.Instance,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", MediaTypeProblemJSON)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+func acceptsXML(r *http.Request) bool {
+	if r == nil {
+		return false
+	}
+	accept := r.Header.Get("Accept")
+	return strings.Contains(accept, "xml") && !strings.Contains(accept, "json")
+}
+
+// ProblemHandler wraps next so that any panic is recovered and reported as
+// an RFC 7807 500 problem instead of crashing the server.
+func ProblemHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				WriteProblem(w, r, ErrInternalError)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewProblemDetailUsesUserDetailsOnly(t *testing.T) {
+	err := New(400, "bad request").
+		WithDetail("sql: row scan failed on column 3").
+		WithUserDetail("the email field is required")
+
+	p := NewProblem(err, "/widgets")
+
+	if p.Detail != "the email field is required" {
+		t.Fatalf("Detail = %q, want only the user-safe detail", p.Detail)
+	}
+	if strings.Contains(p.Detail, "sql:") {
+		t.Fatalf("Detail leaked a technical detail: %q", p.Detail)
+	}
+}
+
+func TestNewProblemDoesNotDuplicateUserDetails(t *testing.T) {
+	err := New(400, "bad request").WithUserDetail("the email field is required")
+
+	p := NewProblem(err, "/widgets")
+
+	if _, ok := p.Extensions["userdetails"]; ok {
+		t.Fatalf("userdetails should not be duplicated into Extensions when it's already in Detail: %v", p.Extensions)
+	}
+}
+
+func TestProblemHandlerRecoversWithoutLeakingPanicValue(t *testing.T) {
+	h := ProblemHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("connection string: postgres://admin:s3cr3t@db/internal")
+	}))
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != 500 {
+		t.Fatalf("status = %d, want 500", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "s3cr3t") {
+		t.Fatalf("response leaked the panic value: %s", rec.Body.String())
+	}
+}
+
+func TestWriteProblemNegotiatesXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+	req.Header.Set("Accept", "application/problem+xml")
+
+	WriteProblem(rec, req, ErrNotFound)
+
+	if ct := rec.Header().Get("Content-Type"); ct != MediaTypeProblemXML {
+		t.Fatalf("Content-Type = %q, want %q", ct, MediaTypeProblemXML)
+	}
+	if !strings.Contains(rec.Body.String(), "<problem>") {
+		t.Fatalf("body does not look like problem XML: %s", rec.Body.String())
+	}
+}
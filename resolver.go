@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"net/http"
+	"sync"
+)
+
+// codeCache holds the lazily-resolved code for a single CError value, so
+// the resolver runs at most once per value. Copy-on-write derivatives get
+// their own cache (see shallowCopy) and resolve independently.
+type codeCache struct {
+	once sync.Once
+	code uint16
+}
+
+var (
+	defaultResolverMu sync.RWMutex
+	defaultResolver   func(*CError) uint16
+)
+
+// RegisterCodeResolver installs the package-wide fallback resolver used by
+// Code() for errors that have no explicit code and no resolver set via
+// WithCodeResolver.
+func RegisterCodeResolver(fn func(*CError) uint16) {
+	defaultResolverMu.Lock()
+	defaultResolver = fn
+	defaultResolverMu.Unlock()
+}
+
+func getDefaultResolver() func(*CError) uint16 {
+	defaultResolverMu.RLock()
+	defer defaultResolverMu.RUnlock()
+	return defaultResolver
+}
+
+// WithCodeResolver attaches fn as the source of e's HTTP code, to be
+// consulted lazily the first time Code() is called on an error with no
+// explicit code. Returns a new error copy for chaining.
+func (e *CError) WithCodeResolver(fn func(*CError) uint16) *CError {
+	newErr := e.shallowCopy()
+	newErr.codeResolver = fn
+	newErr.codeCache = &codeCache{}
+	return &newErr
+}
+
+// shallowCopy returns a field-for-field copy of e with a fresh codeCache.
+// Every copy-on-write builder uses this instead of a bare `newErr := *e` so
+// that sibling derivatives of the same base (e.g. two WithWrap calls on one
+// sentinel) each get their own sync.Once-guarded cache slot, rather than
+// racing to fill and then being stuck with whichever result fired first.
+func (e *CError) shallowCopy() CError {
+	newErr := *e
+	if e.codeCache != nil {
+		newErr.codeCache = &codeCache{}
+	}
+	return newErr
+}
+
+// Code returns the error's HTTP status code as int.
+//
+// If an explicit code was set via New or WithCode, it is returned directly.
+// Otherwise, if a resolver was attached via WithCodeResolver (or installed
+// package-wide via RegisterCodeResolver), it is invoked once and the result
+// is cached for all subsequent calls on e. Copy-on-write derivatives of e
+// start with an empty cache and invoke the resolver again.
+func (e *CError) Code() int {
+	if e.code != 0 {
+		return int(e.code)
+	}
+
+	resolver := e.codeResolver
+	if resolver == nil {
+		resolver = getDefaultResolver()
+	}
+	if resolver == nil {
+		return 0
+	}
+
+	if e.codeCache == nil {
+		return int(resolver(e))
+	}
+
+	e.codeCache.once.Do(func() {
+		e.codeCache.code = resolver(e)
+	})
+	return int(e.codeCache.code)
+}
+
+// IsClientError reports whether Code() falls in the 4xx range.
+func (e *CError) IsClientError() bool {
+	code := e.Code()
+	return code >= 400 && code < 500
+}
+
+// IsServerError reports whether Code() falls in the 5xx range.
+func (e *CError) IsServerError() bool {
+	code := e.Code()
+	return code >= 500 && code < 600
+}
+
+// IsRetriable reports whether Code() is a status that's generally safe to
+// retry: 408, 425, 429, 500, 502, 503, or 504.
+func (e *CError) IsRetriable() bool {
+	switch e.Code() {
+	case 408, 425, 429, 500, 502, 503, 504:
+		return true
+	default:
+		return false
+	}
+}
+
+// HTTPStatusText returns the standard text for Code(), e.g. "Not Found".
+func (e *CError) HTTPStatusText() string {
+	return http.StatusText(e.Code())
+}
+
+// CodeFromChain walks err's wrap chain, including err itself, and returns
+// the first non-zero CError code found. This lets a caller that wraps a
+// lower-level error (e.g. a database timeout) without re-specifying a code
+// still surface the originating status to the transport layer.
+func CodeFromChain(err error) uint16 {
+	for current := err; current != nil; {
+		cerr, ok := current.(*CError)
+		if !ok {
+			unwrapper, ok := current.(interface{ Unwrap() error })
+			if !ok {
+				return 0
+			}
+			current = unwrapper.Unwrap()
+			continue
+		}
+
+		if code := cerr.Code(); code != 0 {
+			return uint16(code)
+		}
+		current = cerr.wrapped
+	}
+	return 0
+}
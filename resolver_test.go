@@ -0,0 +1,69 @@
+package errors
+
+import "testing"
+
+func TestCodeCachesPerErrorResolver(t *testing.T) {
+	calls := 0
+	err := New(0, "boom").WithCodeResolver(func(e *CError) uint16 {
+		calls++
+		return 503
+	})
+
+	for i := 0; i < 3; i++ {
+		if got := err.Code(); got != 503 {
+			t.Fatalf("Code() = %d, want 503", got)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("resolver called %d times, want 1", calls)
+	}
+}
+
+func TestCodeCachesDefaultResolver(t *testing.T) {
+	prev := getDefaultResolver()
+	defer RegisterCodeResolver(prev)
+
+	calls := 0
+	RegisterCodeResolver(func(e *CError) uint16 {
+		calls++
+		return 500
+	})
+
+	err := New(0, "boom")
+	for i := 0; i < 3; i++ {
+		if got := err.Code(); got != 500 {
+			t.Fatalf("Code() = %d, want 500", got)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("default resolver called %d times, want 1", calls)
+	}
+}
+
+func TestCodeCacheDoesNotLeakAcrossSiblingDerivatives(t *testing.T) {
+	base := New(0, "upstream call failed").WithCodeResolver(func(e *CError) uint16 {
+		if wc, ok := e.wrapped.(*CError); ok {
+			return wc.code
+		}
+		return 500
+	})
+
+	a := base.WithWrap(New(504, "gateway timeout"))
+	b := base.WithWrap(New(400, "bad request"))
+
+	if got := a.Code(); got != 504 {
+		t.Fatalf("a.Code() = %d, want 504", got)
+	}
+	if got := b.Code(); got != 400 {
+		t.Fatalf("b.Code() = %d, want 400 (got a's cached code, sibling derivatives shared a cache slot)", got)
+	}
+}
+
+func TestIsRetriable(t *testing.T) {
+	if !New(503, "unavailable").IsRetriable() {
+		t.Fatal("503 should be retriable")
+	}
+	if New(400, "bad request").IsRetriable() {
+		t.Fatal("400 should not be retriable")
+	}
+}
@@ -0,0 +1,122 @@
+package errors
+
+// Category identifies the subsystem a CError originates from, orthogonal to
+// its HTTP code. Services are free to define additional categories beyond
+// the predefined ones below.
+const (
+	CatInput uint16 = iota + 1
+	CatDB
+	CatResource
+	CatAuth
+	CatSystem
+	CatPubSub
+	CatRateLimit
+)
+
+// WithScope sets the service/app id component of the error's taxonomy code
+// and returns a new error copy for chaining.
+func (e *CError) WithScope(scope uint16) *CError {
+	newErr := e.shallowCopy()
+	newErr.scope = scope
+	return &newErr
+}
+
+// WithCategory sets the subsystem component of the error's taxonomy code
+// and returns a new error copy for chaining.
+func (e *CError) WithCategory(category uint16) *CError {
+	newErr := e.shallowCopy()
+	newErr.category = category
+	return &newErr
+}
+
+// WithDetail2 sets the specific-reason component of the error's taxonomy
+// code and returns a new error copy for chaining.
+//
+// It is named WithDetail2 to avoid colliding with the existing WithDetail,
+// which appends a technical log detail rather than a taxonomy code.
+func (e *CError) WithDetail2(detail uint16) *CError {
+	newErr := e.shallowCopy()
+	newErr.detailCode = detail
+	return &newErr
+}
+
+// WithTaxonomy sets scope, category, and detail code in one call and
+// returns a new error copy for chaining.
+func (e *CError) WithTaxonomy(scope, category, detail uint16) *CError {
+	newErr := e.shallowCopy()
+	newErr.scope = scope
+	newErr.category = category
+	newErr.detailCode = detail
+	return &newErr
+}
+
+// Scope returns the error's scope code.
+func (e *CError) Scope() uint16 {
+	return e.scope
+}
+
+// Category returns the error's category code.
+func (e *CError) Category() uint16 {
+	return e.category
+}
+
+// DetailCode returns the error's detail code.
+func (e *CError) DetailCode() uint16 {
+	return e.detailCode
+}
+
+// TaxonomyCode packs scope, category, and detail into a single uint64 as
+// scope<<32 | category<<16 | detail, convenient for logging and indexing.
+func (e *CError) TaxonomyCode() uint64 {
+	return uint64(e.scope)<<32 | uint64(e.category)<<16 | uint64(e.detailCode)
+}
+
+// MatchesCategory reports whether e or any error in its wrap chain has the
+// given category.
+func (e *CError) MatchesCategory(cat uint16) bool {
+	if e.category == cat {
+		return true
+	}
+	for _, wrapped := range e.UnwrapAll() {
+		if wc, ok := wrapped.(*CError); ok && wc.category == cat {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesScope reports whether e or any error in its wrap chain has the
+// given scope.
+func (e *CError) MatchesScope(scope uint16) bool {
+	if e.scope == scope {
+		return true
+	}
+	for _, wrapped := range e.UnwrapAll() {
+		if wc, ok := wrapped.(*CError); ok && wc.scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry lets a service register its scope once and obtain factory
+// methods that construct appropriately-scoped errors without repeating
+// WithScope at every call site.
+type Registry struct {
+	scope uint16
+}
+
+// NewRegistry creates a Registry that tags every error it produces with scope.
+func NewRegistry(scope uint16) *Registry {
+	return &Registry{scope: scope}
+}
+
+// New creates a new CError scoped to r and tagged with category.
+func (r *Registry) New(code uint16, category uint16, msg string) *CError {
+	return New(code, msg).WithScope(r.scope).WithCategory(category)
+}
+
+// Wrap wraps err in a new CError scoped to r and tagged with category.
+func (r *Registry) Wrap(err error, code uint16, category uint16, msg string) *CError {
+	return Wrap(err, code, msg).WithScope(r.scope).WithCategory(category)
+}
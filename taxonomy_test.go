@@ -0,0 +1,36 @@
+package errors
+
+import "testing"
+
+func TestTaxonomyCodePacksComponents(t *testing.T) {
+	err := New(500, "boom").WithTaxonomy(7, CatDB, 3)
+
+	want := uint64(7)<<32 | uint64(CatDB)<<16 | uint64(3)
+	if got := err.TaxonomyCode(); got != want {
+		t.Fatalf("TaxonomyCode() = %d, want %d", got, want)
+	}
+}
+
+func TestMatchesCategoryWalksWrapChain(t *testing.T) {
+	inner := New(500, "db down").WithCategory(CatDB)
+	outer := Wrap(inner, 500, "request failed").WithCategory(CatSystem)
+
+	if !outer.MatchesCategory(CatDB) {
+		t.Fatal("MatchesCategory should find the category on a wrapped error")
+	}
+	if outer.MatchesCategory(CatAuth) {
+		t.Fatal("MatchesCategory should not match an unrelated category")
+	}
+}
+
+func TestRegistryScopesErrors(t *testing.T) {
+	reg := NewRegistry(9)
+	err := reg.New(400, CatInput, "invalid field")
+
+	if err.Scope() != 9 {
+		t.Fatalf("Scope() = %d, want 9", err.Scope())
+	}
+	if !err.MatchesScope(9) {
+		t.Fatal("MatchesScope should match the registry's scope")
+	}
+}